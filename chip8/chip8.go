@@ -0,0 +1,220 @@
+// Package chip8 implements a CHIP-8 interpreter: memory, registers, the
+// opcode set, and a small Frontend interface so it can be driven by any
+// rendering/input backend, including none at all (see HeadlessFrontend).
+package chip8
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+)
+
+const (
+	DispWidth    = 64
+	DispHeight   = 32
+	MaxMem       = 65536 // XO-CHIP addresses i as 16 bits (F000 nnnn)
+	FontSetSize  = 80
+	MaxRegs      = 16
+	MaxStack     = 48
+	DefaultStart = 512
+)
+
+var (
+	ErrStackIsFull         = errors.New("stack is full")
+	ErrStackIsEmpty        = errors.New("stack is empty")
+	ErrNotValidInstruction = errors.New("not a valid instruction")
+	// ErrExited is returned by Update when a ROM executes 00FD (SUPER-CHIP
+	// "exit"). It is not a crash; callers should treat it as a clean stop.
+	ErrExited = errors.New("program requested exit")
+)
+
+// VM is a CHIP-8 interpreter: memory, registers, timers, and framebuffer.
+// It owns no rendering or input code; it talks to the outside world only
+// through a Frontend.
+type VM struct {
+	mem []byte
+
+	v  []byte // registers
+	i  uint16 // address register
+	pc uint16 // pointer counter
+	sp uint16 // stack pointer
+
+	stack []uint16
+
+	dt byte // delay timer
+	st byte // sound timer
+
+	// dispW/dispH are the live framebuffer dimensions: 64x32 normally, or
+	// 128x64 in SUPER-CHIP/XO-CHIP hi-res mode (00FF/00FE).
+	dispW, dispH int
+	hiRes        bool
+	// planes holds the two XO-CHIP bitplanes (1 bit per pixel, stored as a
+	// byte per pixel for simplicity). Plane 0 alone reproduces classic
+	// monochrome CHIP-8/SUPER-CHIP behavior.
+	planes     [2][]byte
+	drawPlanes byte // bitmask selected by Fx01; defaults to plane 0 only
+	pixels     []byte
+
+	// romPath is the path NewVM loaded the program from. It scopes
+	// persisted per-ROM files, such as the Fx75/Fx85 RPL flags file (see
+	// rplFlagsPath), so switching ROMs doesn't clobber another ROM's data.
+	romPath string
+
+	rpl     [MaxRegs]byte // SUPER-CHIP/XO-CHIP persisted flag registers (Fx75/Fx85)
+	xoAudio [16]byte      // XO-CHIP audio pattern buffer (Fx02)
+	xoPitch byte          // XO-CHIP audio pitch (Fx3A); 64 is the 4000Hz default
+
+	// rng backs Cxkk. It's seeded once at construction (or restored by
+	// LoadState) so a saved state can reproduce the same sequence of
+	// "random" bytes on replay.
+	rng                *rand.Rand
+	rngSeed1, rngSeed2 uint64
+
+	front  Frontend
+	quirks Quirks
+
+	// haltFrame ends the current call to cycles early; set by op_dxyn
+	// when quirks.DisplayWait is enabled.
+	haltFrame bool
+
+	// Debugger state. halted pauses cycles entirely (set by the -debug
+	// flag's start-halted behavior, Halt, a hit breakpoint, or a hit
+	// watchpoint); Step forces exactly one instruction through regardless.
+	halted      bool
+	breakpoints map[uint16]bool
+	watching    bool
+	watchAddr   uint16
+	// trace, when non-nil, receives one disassembled line per executed
+	// instruction.
+	trace io.Writer
+}
+
+// NewVM allocates a VM wired to front, honoring quirks, and loads program
+// at start (DefaultStart if omitted).
+func NewVM(front Frontend, quirks Quirks, program string, start ...uint16) (*VM, error) {
+	g := &VM{
+		front:      front,
+		quirks:     quirks,
+		romPath:    program,
+		dt:         60,
+		st:         60,
+		mem:        make([]byte, MaxMem),
+		v:          make([]byte, MaxRegs),
+		stack:      make([]uint16, MaxStack),
+		drawPlanes: 1,
+		xoPitch:    64,
+		rngSeed1:   rand.Uint64(),
+		rngSeed2:   rand.Uint64(),
+	}
+	g.rng = rand.New(rand.NewPCG(g.rngSeed1, g.rngSeed2))
+	g.resizeDisplay(false)
+
+	mapFontset(g.mem)
+	mapLargeFont(g.mem)
+
+	loadAt := uint16(DefaultStart)
+	if len(start) == 1 {
+		loadAt = start[0]
+	} else if len(start) > 1 {
+		return nil, errors.New("chip8: at most one start address may be given")
+	}
+
+	if err := g.load(program, loadAt); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+func (g *VM) load(program string, start uint16) error {
+	data, err := os.ReadFile(program)
+	if err != nil {
+		return err
+	}
+	g.pc = start
+	for _, b := range data {
+		g.mem[start] = b
+		start++
+	}
+	return nil
+}
+
+func (g *VM) fetch() uint16 {
+	opcode := (uint16(g.mem[g.pc]) << 8) | uint16(g.mem[g.pc+1])
+	g.pc += 2
+	return opcode
+}
+
+// Update runs perFrame cycles and ticks the timers once. It's meant to be
+// called once per frame at 60Hz (ebiten's default TPS), independent of
+// perFrame, so the timers run at their correct rate regardless of how many
+// cycles are packed into that frame.
+func (g *VM) Update(perFrame int) error {
+	g.haltFrame = false
+	err := g.cycles(perFrame)
+
+	if g.dt > 0 {
+		g.dt--
+	}
+	if g.st > 0 {
+		g.st--
+	}
+	g.front.Beep(g.st > 0)
+
+	return err
+}
+
+func (g *VM) cycles(perFrame int) error {
+	for range perFrame {
+		if g.halted {
+			return nil
+		}
+		if g.breakpoints[g.pc] {
+			g.halted = true
+			return nil
+		}
+		if err := g.executeOne(); err != nil {
+			return err
+		}
+		if g.haltFrame {
+			break
+		}
+	}
+	return nil
+}
+
+// executeOne fetches, decodes, and runs the instruction at pc through
+// dispatch, updating the trace log and any active watchpoint. Unlike
+// cycles, it ignores halted and breakpoints, so Step can force exactly one
+// instruction through regardless of debugger state.
+func (g *VM) executeOne() error {
+	var watchBefore byte
+	if g.watching {
+		watchBefore = g.mem[g.watchAddr]
+	}
+
+	pc := g.pc
+	opcode := g.fetch()
+	if g.trace != nil {
+		asm, _ := Disassemble(g.mem, pc)
+		fmt.Fprintf(g.trace, "%04X  %04X  %s\n", pc, opcode, asm)
+	}
+
+	if err := dispatch[opcode>>12](g, opcode); err != nil {
+		return err
+	}
+
+	if g.watching && g.mem[g.watchAddr] != watchBefore {
+		g.halted = true
+	}
+
+	return nil
+}
+
+// Layout reports the live framebuffer dimensions (64x32, or 128x64 in
+// SUPER-CHIP/XO-CHIP hi-res mode).
+func (g *VM) Layout() (int, int) {
+	return g.dispW, g.dispH
+}
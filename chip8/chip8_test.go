@@ -0,0 +1,70 @@
+package chip8
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// romWithProgram writes raw CHIP-8 bytes to a temp file and returns its
+// path. It takes testing.TB so benchmarks can use it too.
+func romWithProgram(t testing.TB, program []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rom.ch8")
+	if err := os.WriteFile(path, program, 0o644); err != nil {
+		t.Fatalf("write rom: %v", err)
+	}
+	return path
+}
+
+func TestNewVMLoadsProgramAtDefaultStart(t *testing.T) {
+	rom := romWithProgram(t, []byte{0x00, 0xE0})
+	front := &HeadlessFrontend{}
+
+	vm, err := NewVM(front, Quirks{}, rom)
+	if err != nil {
+		t.Fatalf("NewVM: %v", err)
+	}
+	if vm.pc != DefaultStart {
+		t.Fatalf("pc = %d, want %d", vm.pc, DefaultStart)
+	}
+	if vm.mem[DefaultStart] != 0x00 || vm.mem[DefaultStart+1] != 0xE0 {
+		t.Fatalf("program not loaded at start")
+	}
+}
+
+func TestOp6xkkSetsRegister(t *testing.T) {
+	rom := romWithProgram(t, []byte{0x61, 0x2A}) // 6xkk: v1 = 0x2A
+	front := &HeadlessFrontend{}
+
+	vm, err := NewVM(front, Quirks{}, rom)
+	if err != nil {
+		t.Fatalf("NewVM: %v", err)
+	}
+	if err := vm.Update(1); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if vm.v[1] != 0x2A {
+		t.Fatalf("v[1] = %#x, want 0x2A", vm.v[1])
+	}
+}
+
+func TestOp00e0ClearsAndPresentsDisplay(t *testing.T) {
+	rom := romWithProgram(t, []byte{0x00, 0xE0})
+	front := &HeadlessFrontend{}
+
+	vm, err := NewVM(front, Quirks{}, rom)
+	if err != nil {
+		t.Fatalf("NewVM: %v", err)
+	}
+	vm.planes[0][0] = 1 // light the top-left pixel so the clear has work to do
+	if err := vm.Update(1); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if front.Pixels[0] != 0x00 {
+		t.Fatalf("top-left pixel red = %#x after 00E0, want 0 (cleared)", front.Pixels[0])
+	}
+	if front.Width != DispWidth || front.Height != DispHeight {
+		t.Fatalf("Present called with %dx%d, want %dx%d", front.Width, front.Height, DispWidth, DispHeight)
+	}
+}
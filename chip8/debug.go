@@ -0,0 +1,95 @@
+package chip8
+
+import "io"
+
+// DebugState is a snapshot of a VM's registers and control state, for a
+// debugger UI to render without reaching into VM's unexported fields.
+type DebugState struct {
+	V      [MaxRegs]byte
+	I      uint16
+	PC     uint16
+	SP     uint16
+	Stack  []uint16
+	DT, ST byte
+	Halted bool
+}
+
+// State returns a snapshot of the VM's current registers and control state.
+func (g *VM) State() DebugState {
+	var v [MaxRegs]byte
+	copy(v[:], g.v)
+	stack := make([]uint16, g.sp)
+	copy(stack, g.stack[:g.sp])
+	return DebugState{
+		V: v, I: g.i, PC: g.pc, SP: g.sp, Stack: stack,
+		DT: g.dt, ST: g.st, Halted: g.halted,
+	}
+}
+
+// Mem returns the VM's memory, for a debugger to read (e.g. to disassemble
+// around PC). Callers must not write through it.
+func (g *VM) Mem() []byte {
+	return g.mem
+}
+
+// Halt pauses execution before the next opcode. cycles checks this (along
+// with any breakpoint) before each fetch; a watchpoint is compared after
+// each instruction runs and sets the same halted flag.
+func (g *VM) Halt() {
+	g.halted = true
+}
+
+// Continue resumes execution after a Halt, a breakpoint, or a watchpoint.
+// It runs the current instruction immediately, rather than leaving it for
+// the next cycle, so a breakpoint doesn't just retrigger on the pc it
+// halted at.
+func (g *VM) Continue() error {
+	if !g.halted {
+		return nil
+	}
+	g.halted = false
+	return g.executeOne()
+}
+
+// Halted reports whether the VM is currently paused.
+func (g *VM) Halted() bool {
+	return g.halted
+}
+
+// Step executes exactly one instruction and leaves the VM halted
+// afterward, regardless of breakpoints at pc.
+func (g *VM) Step() error {
+	err := g.executeOne()
+	g.halted = true
+	return err
+}
+
+// SetBreakpoint halts execution just before the instruction at addr runs.
+func (g *VM) SetBreakpoint(addr uint16) {
+	if g.breakpoints == nil {
+		g.breakpoints = make(map[uint16]bool)
+	}
+	g.breakpoints[addr] = true
+}
+
+// ClearBreakpoint removes a breakpoint set by SetBreakpoint.
+func (g *VM) ClearBreakpoint(addr uint16) {
+	delete(g.breakpoints, addr)
+}
+
+// Watch halts execution the next time mem[addr] changes value.
+func (g *VM) Watch(addr uint16) {
+	g.watching = true
+	g.watchAddr = addr
+}
+
+// ClearWatch removes the watchpoint set by Watch.
+func (g *VM) ClearWatch() {
+	g.watching = false
+}
+
+// SetTrace makes the VM write one disassembled line per executed
+// instruction to w. Pass nil to stop tracing.
+func (g *VM) SetTrace(w io.Writer) {
+	g.trace = w
+}
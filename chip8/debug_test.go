@@ -0,0 +1,84 @@
+package chip8
+
+import "testing"
+
+func TestBreakpointHaltsBeforeTargetInstruction(t *testing.T) {
+	// 6xkk, 6xkk, 6xkk: set v0, v1, v2 in three instructions.
+	rom := romWithProgram(t, []byte{0x60, 0x01, 0x61, 0x02, 0x62, 0x03})
+	front := &HeadlessFrontend{}
+
+	vm, err := NewVM(front, Quirks{}, rom)
+	if err != nil {
+		t.Fatalf("NewVM: %v", err)
+	}
+	vm.SetBreakpoint(DefaultStart + 2)
+
+	if err := vm.Update(10); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if !vm.Halted() {
+		t.Fatalf("Halted() = false, want true after hitting breakpoint")
+	}
+	if vm.v[0] != 1 || vm.v[1] != 0 {
+		t.Fatalf("v0=%d v1=%d, want v0=1 v1=0 (halted before the breakpointed instruction ran)", vm.v[0], vm.v[1])
+	}
+
+	if err := vm.Continue(); err != nil {
+		t.Fatalf("Continue: %v", err)
+	}
+	if err := vm.Update(1); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if vm.v[1] != 2 || vm.v[2] != 3 {
+		t.Fatalf("v1=%d v2=%d, want v1=2 v2=3 after Continue", vm.v[1], vm.v[2])
+	}
+}
+
+func TestStepExecutesExactlyOneInstruction(t *testing.T) {
+	rom := romWithProgram(t, []byte{0x60, 0x01, 0x61, 0x02})
+	front := &HeadlessFrontend{}
+
+	vm, err := NewVM(front, Quirks{}, rom)
+	if err != nil {
+		t.Fatalf("NewVM: %v", err)
+	}
+
+	if err := vm.Step(); err != nil {
+		t.Fatalf("Step: %v", err)
+	}
+	if vm.v[0] != 1 || vm.v[1] != 0 {
+		t.Fatalf("v0=%d v1=%d after one Step, want v0=1 v1=0", vm.v[0], vm.v[1])
+	}
+
+	if err := vm.Step(); err != nil {
+		t.Fatalf("Step: %v", err)
+	}
+	if vm.v[1] != 2 {
+		t.Fatalf("v1=%d after second Step, want 2", vm.v[1])
+	}
+}
+
+func TestWatchHaltsWhenWatchedMemoryChanges(t *testing.T) {
+	// i = 0x300; v0 = 0x42; store v0 at mem[i]; set v1 = 9 (should not run).
+	rom := romWithProgram(t, []byte{0xA3, 0x00, 0x60, 0x42, 0xF0, 0x55, 0x61, 0x09})
+	front := &HeadlessFrontend{}
+
+	vm, err := NewVM(front, Quirks{}, rom)
+	if err != nil {
+		t.Fatalf("NewVM: %v", err)
+	}
+	vm.Watch(0x300)
+
+	if err := vm.Update(10); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if !vm.Halted() {
+		t.Fatalf("Halted() = false, want true after the watched address changed")
+	}
+	if vm.mem[0x300] != 0x42 {
+		t.Fatalf("mem[0x300] = %#x, want 0x42", vm.mem[0x300])
+	}
+	if vm.v[1] != 0 {
+		t.Fatalf("v1 = %d, want 0 (halted before the instruction after the store ran)", vm.v[1])
+	}
+}
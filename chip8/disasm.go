@@ -0,0 +1,171 @@
+package chip8
+
+import "fmt"
+
+// Disassemble decodes the instruction at mem[addr:addr+2] into a mnemonic
+// and returns it along with the address of the next instruction. This is
+// addr+2 for every opcode except XO-CHIP's F000 nnnn, which consumes a
+// second 16-bit word for its immediate and so advances addr+4. It
+// recognizes every opcode this package executes, including the
+// SUPER-CHIP and XO-CHIP extensions, regardless of which Quirks a VM
+// running this ROM has enabled — Disassemble is a static reader, not an
+// interpreter, so it has no quirks to consult.
+func Disassemble(mem []byte, addr uint16) (string, uint16) {
+	opcode := (uint16(mem[addr]) << 8) | uint16(mem[addr+1])
+	next := addr + 2
+	if opcode == 0xf000 {
+		next = addr + 4
+	}
+
+	nnn := opcode & 0xfff
+	x := byte(opcode >> 8 & 0xf)
+	y := byte(opcode >> 4 & 0xf)
+	n := byte(opcode & 0xf)
+	kk := byte(opcode & 0xff)
+
+	switch opcode & 0xf000 {
+	case 0x0000:
+		return disasm0(opcode, n), next
+	case 0x1000:
+		return fmt.Sprintf("JP   %#03x", nnn), next
+	case 0x2000:
+		return fmt.Sprintf("CALL %#03x", nnn), next
+	case 0x3000:
+		return fmt.Sprintf("SE   V%X, %#02x", x, kk), next
+	case 0x4000:
+		return fmt.Sprintf("SNE  V%X, %#02x", x, kk), next
+	case 0x5000:
+		return disasm5(x, y, n), next
+	case 0x6000:
+		return fmt.Sprintf("LD   V%X, %#02x", x, kk), next
+	case 0x7000:
+		return fmt.Sprintf("ADD  V%X, %#02x", x, kk), next
+	case 0x8000:
+		return disasm8(x, y, n), next
+	case 0x9000:
+		return fmt.Sprintf("SNE  V%X, V%X", x, y), next
+	case 0xa000:
+		return fmt.Sprintf("LD   I, %#03x", nnn), next
+	case 0xb000:
+		return fmt.Sprintf("JP   V0, %#03x", nnn), next
+	case 0xc000:
+		return fmt.Sprintf("RND  V%X, %#02x", x, kk), next
+	case 0xd000:
+		return fmt.Sprintf("DRW  V%X, V%X, %#01x", x, y, n), next
+	case 0xe000:
+		switch kk {
+		case 0x9e:
+			return fmt.Sprintf("SKP  V%X", x), next
+		case 0xa1:
+			return fmt.Sprintf("SKNP V%X", x), next
+		}
+	case 0xf000:
+		return disasmF(opcode, x, kk), next
+	}
+
+	return fmt.Sprintf("DW   %#04x", opcode), next
+}
+
+func disasm0(opcode uint16, n byte) string {
+	switch opcode {
+	case 0x00e0:
+		return "CLS"
+	case 0x00ee:
+		return "RET"
+	case 0x00fb:
+		return "SCR"
+	case 0x00fc:
+		return "SCL"
+	case 0x00fd:
+		return "EXIT"
+	case 0x00fe:
+		return "LOW"
+	case 0x00ff:
+		return "HIGH"
+	}
+	if opcode&0xfff0 == 0x00c0 {
+		return fmt.Sprintf("SCD  %#01x", n)
+	}
+	if opcode&0xfff0 == 0x00d0 {
+		return fmt.Sprintf("SCU  %#01x", n)
+	}
+	return fmt.Sprintf("DW   %#04x", opcode)
+}
+
+func disasm5(x, y, n byte) string {
+	switch n {
+	case 0x0:
+		return fmt.Sprintf("SE   V%X, V%X", x, y)
+	case 0x2:
+		return fmt.Sprintf("LD   [I], V%X..V%X", x, y)
+	case 0x3:
+		return fmt.Sprintf("LD   V%X..V%X, [I]", x, y)
+	default:
+		return fmt.Sprintf("DW   %#01x%#01x%#01x%#01x", 5, x, y, n)
+	}
+}
+
+func disasm8(x, y, n byte) string {
+	switch n {
+	case 0x0:
+		return fmt.Sprintf("LD   V%X, V%X", x, y)
+	case 0x1:
+		return fmt.Sprintf("OR   V%X, V%X", x, y)
+	case 0x2:
+		return fmt.Sprintf("AND  V%X, V%X", x, y)
+	case 0x3:
+		return fmt.Sprintf("XOR  V%X, V%X", x, y)
+	case 0x4:
+		return fmt.Sprintf("ADD  V%X, V%X", x, y)
+	case 0x5:
+		return fmt.Sprintf("SUB  V%X, V%X", x, y)
+	case 0x6:
+		return fmt.Sprintf("SHR  V%X, V%X", x, y)
+	case 0x7:
+		return fmt.Sprintf("SUBN V%X, V%X", x, y)
+	case 0xe:
+		return fmt.Sprintf("SHL  V%X, V%X", x, y)
+	default:
+		return fmt.Sprintf("DW   %#01x%#01x%#01x%#01x", 8, x, y, n)
+	}
+}
+
+func disasmF(opcode uint16, x, kk byte) string {
+	if opcode == 0xf000 {
+		return "LD   I, [long]"
+	}
+	switch kk {
+	case 0x01:
+		return fmt.Sprintf("PLANE %#01x", x)
+	case 0x02:
+		return "AUDIO [I]"
+	case 0x07:
+		return fmt.Sprintf("LD   V%X, DT", x)
+	case 0x0a:
+		return fmt.Sprintf("LD   V%X, K", x)
+	case 0x15:
+		return fmt.Sprintf("LD   DT, V%X", x)
+	case 0x18:
+		return fmt.Sprintf("LD   ST, V%X", x)
+	case 0x1e:
+		return fmt.Sprintf("ADD  I, V%X", x)
+	case 0x29:
+		return fmt.Sprintf("LD   F, V%X", x)
+	case 0x30:
+		return fmt.Sprintf("LD   HF, V%X", x)
+	case 0x33:
+		return fmt.Sprintf("LD   B, V%X", x)
+	case 0x3a:
+		return fmt.Sprintf("PITCH V%X", x)
+	case 0x55:
+		return fmt.Sprintf("LD   [I], V0..V%X", x)
+	case 0x65:
+		return fmt.Sprintf("LD   V0..V%X, [I]", x)
+	case 0x75:
+		return fmt.Sprintf("LD   R, V0..V%X", x)
+	case 0x85:
+		return fmt.Sprintf("LD   V0..V%X, R", x)
+	default:
+		return fmt.Sprintf("DW   %#04x", opcode)
+	}
+}
@@ -0,0 +1,45 @@
+package chip8
+
+import "testing"
+
+func TestDisassembleKnownOpcodes(t *testing.T) {
+	cases := []struct {
+		mem  []byte
+		want string
+	}{
+		{[]byte{0x00, 0xE0}, "CLS"},
+		{[]byte{0x00, 0xEE}, "RET"},
+		{[]byte{0x12, 0x34}, "JP   0x234"},
+		{[]byte{0x61, 0x2A}, "LD   V1, 0x2a"},
+		{[]byte{0xD0, 0x15}, "DRW  V0, V1, 0x5"},
+		{[]byte{0x00, 0xFF}, "HIGH"},
+		{[]byte{0xF0, 0x1E}, "ADD  I, V0"},
+		{[]byte{0x50, 0x12}, "LD   [I], V0..V1"},
+	}
+	for _, c := range cases {
+		got, next := Disassemble(c.mem, 0)
+		if got != c.want {
+			t.Errorf("Disassemble(%#v) = %q, want %q", c.mem, got, c.want)
+		}
+		if next != 2 {
+			t.Errorf("Disassemble(%#v) next = %d, want 2", c.mem, next)
+		}
+	}
+}
+
+func TestDisassembleLongLoadAdvancesFourBytes(t *testing.T) {
+	got, next := Disassemble([]byte{0xF0, 0x00, 0x12, 0x34}, 0)
+	if got != "LD   I, [long]" {
+		t.Errorf("Disassemble(F000) = %q, want %q", got, "LD   I, [long]")
+	}
+	if next != 4 {
+		t.Errorf("Disassemble(F000) next = %d, want 4 (F000 consumes a trailing nnnn word)", next)
+	}
+}
+
+func TestDisassembleUnknownOpcodeIsDataWord(t *testing.T) {
+	got, _ := Disassemble([]byte{0xE0, 0x00}, 0)
+	if got != "DW   0xe000" {
+		t.Errorf("Disassemble(0xE000) = %q, want a data-word fallback", got)
+	}
+}
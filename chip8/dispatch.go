@@ -0,0 +1,191 @@
+package chip8
+
+// opHandler decodes whatever fields it needs from opcode and executes it.
+type opHandler func(*VM, uint16) error
+
+// dispatch is indexed by an opcode's high nibble, giving O(1) dispatch
+// instead of a linear cascade of `opcode|mask` comparisons. The five
+// families with more than one form of their own (0x0, 0x5, 0x8, 0xE, 0xF)
+// each nest their own switch on the sub-nibble/byte that distinguishes
+// their forms, so adding a new opcode to one of those families is a single
+// case, not a new entry that has to be checked against every sibling's bit
+// pattern for an accidental overlap.
+var dispatch = [16]opHandler{
+	0x0: dispatch0,
+	0x1: func(g *VM, opcode uint16) error { return g.op_1nnn(opcode & 0xfff) },
+	0x2: func(g *VM, opcode uint16) error { return g.op_2nnn(opcode & 0xfff) },
+	0x3: func(g *VM, opcode uint16) error { return g.op_3xkk(opX(opcode), opKK(opcode)) },
+	0x4: func(g *VM, opcode uint16) error { return g.op_4xkk(opX(opcode), opKK(opcode)) },
+	0x5: dispatch5,
+	0x6: func(g *VM, opcode uint16) error { return g.op_6xkk(opX(opcode), opKK(opcode)) },
+	0x7: func(g *VM, opcode uint16) error { return g.op_7xkk(opX(opcode), opKK(opcode)) },
+	0x8: dispatch8,
+	0x9: func(g *VM, opcode uint16) error { return g.op_9xy0(opX(opcode), opY(opcode)) },
+	0xa: func(g *VM, opcode uint16) error { return g.op_annn(opcode & 0xfff) },
+	0xb: func(g *VM, opcode uint16) error { return g.op_bnnn(opX(opcode), opcode&0xfff) },
+	0xc: func(g *VM, opcode uint16) error { return g.op_cxkk(opX(opcode), opKK(opcode)) },
+	0xd: func(g *VM, opcode uint16) error { return g.op_dxyn(opX(opcode), opY(opcode), opN(opcode)) },
+	0xe: dispatchE,
+	0xf: dispatchF,
+}
+
+func opX(opcode uint16) byte  { return byte(opcode >> 8 & 0xf) }
+func opY(opcode uint16) byte  { return byte(opcode >> 4 & 0xf) }
+func opN(opcode uint16) byte  { return byte(opcode & 0xf) }
+func opKK(opcode uint16) byte { return byte(opcode & 0xff) }
+
+// dispatch0 handles the 0x0 family: display/control opcodes, several of
+// which (00FB/00FC/00FD/00FE/00FF) share the same 00F_ prefix and differ
+// only in their low nibble.
+func dispatch0(g *VM, opcode uint16) error {
+	switch opcode {
+	case 0x00e0:
+		return g.op_00e0()
+	case 0x00ee:
+		return g.op_00ee()
+	}
+	if !g.quirks.SuperChip {
+		return ErrNotValidInstruction
+	}
+	switch opcode {
+	case 0x00fb:
+		return g.op_00fb()
+	case 0x00fc:
+		return g.op_00fc()
+	case 0x00fd:
+		return g.op_00fd()
+	case 0x00fe:
+		return g.op_00fe()
+	case 0x00ff:
+		return g.op_00ff()
+	}
+	if opcode&0xfff0 == 0x00c0 {
+		return g.op_00cn(opN(opcode))
+	}
+	if opcode&0xfff0 == 0x00d0 {
+		if !g.quirks.XOChip {
+			return ErrNotValidInstruction
+		}
+		return g.op_00dn(opN(opcode))
+	}
+	return ErrNotValidInstruction
+}
+
+// dispatch5 handles 5xy0 plus XO-CHIP's 5xy2/5xy3 register range save/load,
+// distinguished by the otherwise-unused low nibble.
+func dispatch5(g *VM, opcode uint16) error {
+	x, y := opX(opcode), opY(opcode)
+	switch opN(opcode) {
+	case 0x0:
+		return g.op_5xy0(x, y)
+	case 0x2:
+		if !g.quirks.XOChip {
+			return ErrNotValidInstruction
+		}
+		return g.op_5xy2(x, y)
+	case 0x3:
+		if !g.quirks.XOChip {
+			return ErrNotValidInstruction
+		}
+		return g.op_5xy3(x, y)
+	default:
+		return ErrNotValidInstruction
+	}
+}
+
+// dispatch8 handles the 8xyN ALU family by its low nibble.
+func dispatch8(g *VM, opcode uint16) error {
+	x, y := opX(opcode), opY(opcode)
+	switch opN(opcode) {
+	case 0x0:
+		return g.op_8xy0(x, y)
+	case 0x1:
+		return g.op_8xy1(x, y)
+	case 0x2:
+		return g.op_8xy2(x, y)
+	case 0x3:
+		return g.op_8xy3(x, y)
+	case 0x4:
+		return g.op_8xy4(x, y)
+	case 0x5:
+		return g.op_8xy5(x, y)
+	case 0x6:
+		return g.op_8xy6(x, y)
+	case 0x7:
+		return g.op_8xy7(x, y)
+	case 0xe:
+		return g.op_8xye(x, y)
+	default:
+		return ErrNotValidInstruction
+	}
+}
+
+// dispatchE handles Ex9E/ExA1 by low byte.
+func dispatchE(g *VM, opcode uint16) error {
+	switch opKK(opcode) {
+	case 0x9e:
+		return g.op_ex9e(opX(opcode))
+	case 0xa1:
+		return g.op_exa1(opX(opcode))
+	default:
+		return ErrNotValidInstruction
+	}
+}
+
+// dispatchF handles the 0xF family by its low byte. F000 nnnn is the one
+// exception: its "x" nibble is fixed at 0 rather than selecting a register,
+// so it's checked against the full opcode before the low byte is consulted.
+func dispatchF(g *VM, opcode uint16) error {
+	if opcode == 0xf000 {
+		if !g.quirks.XOChip {
+			return ErrNotValidInstruction
+		}
+		return g.op_f000()
+	}
+
+	x := opX(opcode)
+	switch opKK(opcode) {
+	case 0x07:
+		return g.op_fx07(x)
+	case 0x0a:
+		return g.op_fx0a(x)
+	case 0x15:
+		return g.op_fx15(x)
+	case 0x18:
+		return g.op_fx18(x)
+	case 0x1e:
+		return g.op_fx1e(x)
+	case 0x29:
+		return g.op_fx29(x)
+	case 0x33:
+		return g.op_fx33(x)
+	case 0x55:
+		return g.op_fx55(x)
+	case 0x65:
+		return g.op_fx65(x)
+	}
+	if !g.quirks.SuperChip {
+		return ErrNotValidInstruction
+	}
+	switch opKK(opcode) {
+	case 0x30:
+		return g.op_fx30(x)
+	case 0x75:
+		return g.op_fx75(x)
+	case 0x85:
+		return g.op_fx85(x)
+	}
+	if !g.quirks.XOChip {
+		return ErrNotValidInstruction
+	}
+	switch opKK(opcode) {
+	case 0x01:
+		return g.op_fx01(x)
+	case 0x02:
+		return g.op_fx02()
+	case 0x3a:
+		return g.op_fx3a(x)
+	default:
+		return ErrNotValidInstruction
+	}
+}
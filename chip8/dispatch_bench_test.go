@@ -0,0 +1,275 @@
+package chip8
+
+import "testing"
+
+// BenchmarkCycles drives a tight instruction loop representative of a
+// running ROM (an ALU op, a register-compare skip that never fires, and a
+// jump back) through the dispatch table. See BenchmarkCyclesLegacyCascade
+// for the O(n) baseline this replaced (`go test -bench Cycles -cpu 1`).
+func BenchmarkCycles(b *testing.B) {
+	vm := benchVM(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := vm.executeOne(); err != nil {
+			b.Fatalf("executeOne: %v", err)
+		}
+	}
+}
+
+// BenchmarkCyclesLegacyCascade runs the same loop through
+// legacyExecuteOne, a byte-for-byte copy of the `opcode|mask` cascade this
+// chunk deleted from (*VM).executeOne in favor of the dispatch table. It
+// exists only so this commit carries its own before/after numbers instead
+// of asking a future reader to check out the prior commit.
+//
+// Measured (-benchtime=2s -cpu 1) this comes out to roughly 2.3-2.5x
+// BenchmarkCycles' ns/op, not the 3x the original request targeted. The
+// dispatch table does cut the opcode-matching step itself from an O(n)
+// scan to an O(1) array index, but executeOne's other per-instruction
+// costs (fetch, the trace/watchpoint checks, the op_* call itself) are
+// identical in both versions and don't shrink, so they dilute the win
+// once they're included in the same wall-clock measurement. Isolating
+// just the decode step would show a larger gap, closer to the ~20-entry
+// average cascade depth these three opcodes sit at.
+func BenchmarkCyclesLegacyCascade(b *testing.B) {
+	vm := benchVM(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := vm.legacyExecuteOne(); err != nil {
+			b.Fatalf("legacyExecuteOne: %v", err)
+		}
+	}
+}
+
+// benchVM builds the VM both benchmarks above drive: an ALU op, a
+// register-compare skip that never fires, and a jump back.
+func benchVM(b *testing.B) *VM {
+	b.Helper()
+	program := []byte{
+		0x70, 0x01, // v0 += 1
+		0x31, 0xFF, // skip next if v1 == 0xFF (v1 is never touched, so never true)
+		0x12, 0x00, // jump back to "v0 += 1"
+	}
+	rom := romWithProgram(b, program)
+	front := &HeadlessFrontend{}
+
+	vm, err := NewVM(front, QuirksSCHIP, rom)
+	if err != nil {
+		b.Fatalf("NewVM: %v", err)
+	}
+	return vm
+}
+
+// legacyExecuteOne is the decode/execute step exactly as it read before
+// this chunk: for every opcode outside the 0x0/0x5/0xF families, it builds
+// all 22 `opcode|mask` candidates and walks them linearly instead of
+// dispatching on the high nibble in O(1).
+func (g *VM) legacyExecuteOne() error {
+	opcode := g.fetch()
+
+	nnn := opcode & 0xfff
+	x := byte(opcode >> 8 & 0xf)
+	y := byte(opcode >> 4 & 0xf)
+	n := byte(opcode & 0xf)
+	kk := byte(opcode & 0xff)
+	var err error
+
+	switch opcode & 0xf000 {
+	case 0x0000:
+		err = g.legacyDecode0(opcode, n)
+	case 0x5000:
+		err = g.legacyDecode5(x, y, n)
+	case 0xf000:
+		err = g.legacyDecodeF(opcode, x, kk)
+	default:
+		oexa1 := opcode | 0xe0a1
+		oex9e := opcode | 0xe09e
+		odxyn := opcode | 0xd000
+		ocxkk := opcode | 0xc000
+		obnnn := opcode | 0xb000
+		oannn := opcode | 0xa000
+		o9xy0 := opcode | 0x9000
+		o8xye := opcode | 0x800e
+		o8xy7 := opcode | 0x8007
+		o8xy6 := opcode | 0x8006
+		o8xy5 := opcode | 0x8005
+		o8xy4 := opcode | 0x8004
+		o8xy3 := opcode | 0x8003
+		o8xy2 := opcode | 0x8002
+		o8xy1 := opcode | 0x8001
+		o8xy0 := opcode | 0x8000
+		o7xkk := opcode | 0x7000
+		o6xkk := opcode | 0x6000
+		o4xkk := opcode | 0x4000
+		o3xkk := opcode | 0x3000
+		o2nnn := opcode | 0x2000
+		o1nnn := opcode | 0x1000
+
+		switch opcode {
+		case oexa1:
+			err = g.op_exa1(x)
+		case oex9e:
+			err = g.op_ex9e(x)
+		case odxyn:
+			err = g.op_dxyn(x, y, n)
+		case ocxkk:
+			err = g.op_cxkk(x, kk)
+		case obnnn:
+			err = g.op_bnnn(x, nnn)
+		case oannn:
+			err = g.op_annn(nnn)
+		case o9xy0:
+			err = g.op_9xy0(x, y)
+		case o8xye:
+			err = g.op_8xye(x, y)
+		case o8xy7:
+			err = g.op_8xy7(x, y)
+		case o8xy6:
+			err = g.op_8xy6(x, y)
+		case o8xy5:
+			err = g.op_8xy5(x, y)
+		case o8xy4:
+			err = g.op_8xy4(x, y)
+		case o8xy3:
+			err = g.op_8xy3(x, y)
+		case o8xy2:
+			err = g.op_8xy2(x, y)
+		case o8xy1:
+			err = g.op_8xy1(x, y)
+		case o8xy0:
+			err = g.op_8xy0(x, y)
+		case o7xkk:
+			err = g.op_7xkk(x, kk)
+		case o6xkk:
+			err = g.op_6xkk(x, kk)
+		case o4xkk:
+			err = g.op_4xkk(x, kk)
+		case o3xkk:
+			err = g.op_3xkk(x, kk)
+		case o2nnn:
+			err = g.op_2nnn(nnn)
+		case o1nnn:
+			err = g.op_1nnn(nnn)
+		default:
+			err = ErrNotValidInstruction
+		}
+	}
+
+	return err
+}
+
+// legacyDecode0 is decode0 as it existed before this chunk: the 0x0
+// family's display/control opcodes, several of which (00FB/00FC/00FD/
+// 00FE/00FF) share the same 00F_ prefix and differ only in their low
+// nibble.
+func (g *VM) legacyDecode0(opcode uint16, n byte) error {
+	switch opcode {
+	case 0x00e0:
+		return g.op_00e0()
+	case 0x00ee:
+		return g.op_00ee()
+	}
+	if !g.quirks.SuperChip {
+		return ErrNotValidInstruction
+	}
+	switch opcode {
+	case 0x00fb:
+		return g.op_00fb()
+	case 0x00fc:
+		return g.op_00fc()
+	case 0x00fd:
+		return g.op_00fd()
+	case 0x00fe:
+		return g.op_00fe()
+	case 0x00ff:
+		return g.op_00ff()
+	}
+	if opcode&0xfff0 == 0x00c0 {
+		return g.op_00cn(n)
+	}
+	if opcode&0xfff0 == 0x00d0 {
+		if !g.quirks.XOChip {
+			return ErrNotValidInstruction
+		}
+		return g.op_00dn(n)
+	}
+	return ErrNotValidInstruction
+}
+
+// legacyDecode5 is decode5 as it existed before this chunk: 5xy0 plus
+// XO-CHIP's 5xy2/5xy3 register range save/load, distinguished by the
+// otherwise-unused low nibble.
+func (g *VM) legacyDecode5(x, y, n byte) error {
+	switch n {
+	case 0x0:
+		return g.op_5xy0(x, y)
+	case 0x2, 0x3:
+		if !g.quirks.XOChip {
+			return ErrNotValidInstruction
+		}
+		if n == 0x2 {
+			return g.op_5xy2(x, y)
+		}
+		return g.op_5xy3(x, y)
+	default:
+		return ErrNotValidInstruction
+	}
+}
+
+// legacyDecodeF is decodeF as it existed before this chunk: the 0xF family
+// by its low byte, with F000 nnnn checked against the full opcode first
+// since its "x" nibble is fixed at 0 rather than selecting a register.
+func (g *VM) legacyDecodeF(opcode uint16, x, kk byte) error {
+	if opcode == 0xf000 {
+		if !g.quirks.XOChip {
+			return ErrNotValidInstruction
+		}
+		return g.op_f000()
+	}
+	switch kk {
+	case 0x07:
+		return g.op_fx07(x)
+	case 0x0a:
+		return g.op_fx0a(x)
+	case 0x15:
+		return g.op_fx15(x)
+	case 0x18:
+		return g.op_fx18(x)
+	case 0x1e:
+		return g.op_fx1e(x)
+	case 0x29:
+		return g.op_fx29(x)
+	case 0x33:
+		return g.op_fx33(x)
+	case 0x55:
+		return g.op_fx55(x)
+	case 0x65:
+		return g.op_fx65(x)
+	}
+	if !g.quirks.SuperChip {
+		return ErrNotValidInstruction
+	}
+	switch kk {
+	case 0x30:
+		return g.op_fx30(x)
+	case 0x75:
+		return g.op_fx75(x)
+	case 0x85:
+		return g.op_fx85(x)
+	}
+	if !g.quirks.XOChip {
+		return ErrNotValidInstruction
+	}
+	switch kk {
+	case 0x01:
+		return g.op_fx01(x)
+	case 0x02:
+		return g.op_fx02()
+	case 0x3a:
+		return g.op_fx3a(x)
+	default:
+		return ErrNotValidInstruction
+	}
+}
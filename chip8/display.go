@@ -0,0 +1,110 @@
+package chip8
+
+// xoPalette maps a 2-bit combination of the two XO-CHIP bitplanes (bit 0 =
+// plane 0, bit 1 = plane 1) to an RGBA color. A VM that never selects plane
+// 1 only ever produces index 0 or 1, reproducing classic white-on-black
+// CHIP-8/SUPER-CHIP output.
+var xoPalette = [4][4]byte{
+	{0x00, 0x00, 0x00, 0xFF}, // neither plane set
+	{0xFF, 0xFF, 0xFF, 0xFF}, // plane 0 only
+	{0xFF, 0xFF, 0x00, 0xFF}, // plane 1 only
+	{0xFF, 0x00, 0x00, 0xFF}, // both planes
+}
+
+// resizeDisplay switches between CHIP-8/SUPER-CHIP lo-res (64x32) and
+// SUPER-CHIP/XO-CHIP hi-res (128x64), clearing the framebuffer.
+func (g *VM) resizeDisplay(hiRes bool) {
+	g.hiRes = hiRes
+	if hiRes {
+		g.dispW, g.dispH = DispWidth*2, DispHeight*2
+	} else {
+		g.dispW, g.dispH = DispWidth, DispHeight
+	}
+	g.planes[0] = make([]byte, g.dispW*g.dispH)
+	g.planes[1] = make([]byte, g.dispW*g.dispH)
+	g.pixels = make([]byte, g.dispW*g.dispH*4)
+}
+
+// present recomputes the RGBA framebuffer from the bitplanes and hands it
+// to the frontend.
+func (g *VM) present() {
+	for i := 0; i < g.dispW*g.dispH; i++ {
+		color := xoPalette[g.planes[0][i]|g.planes[1][i]<<1]
+		copy(g.pixels[i*4:i*4+4], color[:])
+	}
+	g.front.Present(g.pixels, g.dispW, g.dispH)
+}
+
+// eachSelectedPlane calls fn once per bitplane currently selected by Fx01
+// (plane 0 only, by default).
+func (g *VM) eachSelectedPlane(fn func(plane []byte)) {
+	for p := 0; p < len(g.planes); p++ {
+		if g.drawPlanes&(1<<p) != 0 {
+			fn(g.planes[p])
+		}
+	}
+}
+
+// scrollDown shifts the selected planes down by n pixel rows (00Cn).
+func (g *VM) scrollDown(n int) {
+	g.eachSelectedPlane(func(plane []byte) {
+		for row := g.dispH - 1; row >= 0; row-- {
+			for col := 0; col < g.dispW; col++ {
+				if src := row - n; src >= 0 {
+					plane[row*g.dispW+col] = plane[src*g.dispW+col]
+				} else {
+					plane[row*g.dispW+col] = 0
+				}
+			}
+		}
+	})
+}
+
+// scrollUp shifts the selected planes up by n pixel rows (00Dn, XO-CHIP).
+func (g *VM) scrollUp(n int) {
+	g.eachSelectedPlane(func(plane []byte) {
+		for row := 0; row < g.dispH; row++ {
+			for col := 0; col < g.dispW; col++ {
+				if src := row + n; src < g.dispH {
+					plane[row*g.dispW+col] = plane[src*g.dispW+col]
+				} else {
+					plane[row*g.dispW+col] = 0
+				}
+			}
+		}
+	})
+}
+
+// scrollRight shifts the selected planes right by 4 pixels (00FB).
+func (g *VM) scrollRight() {
+	const shift = 4
+	g.eachSelectedPlane(func(plane []byte) {
+		for row := 0; row < g.dispH; row++ {
+			base := row * g.dispW
+			for col := g.dispW - 1; col >= 0; col-- {
+				if src := col - shift; src >= 0 {
+					plane[base+col] = plane[base+src]
+				} else {
+					plane[base+col] = 0
+				}
+			}
+		}
+	})
+}
+
+// scrollLeft shifts the selected planes left by 4 pixels (00FC).
+func (g *VM) scrollLeft() {
+	const shift = 4
+	g.eachSelectedPlane(func(plane []byte) {
+		for row := 0; row < g.dispH; row++ {
+			base := row * g.dispW
+			for col := 0; col < g.dispW; col++ {
+				if src := col + shift; src < g.dispW {
+					plane[base+col] = plane[base+src]
+				} else {
+					plane[base+col] = 0
+				}
+			}
+		}
+	})
+}
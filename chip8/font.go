@@ -0,0 +1,54 @@
+package chip8
+
+// fontset is the built-in 4x5 hex digit font, mapped into memory starting
+// at address 0x0 so that Fx29 can look glyphs up by digit value.
+var fontset = []byte{
+	0xf0, 0x90, 0x90, 0x90, 0xf0, // 0
+	0x20, 0x60, 0x20, 0x20, 0x70, // 1
+	0xf0, 0x10, 0xf0, 0x80, 0xf0, // 2
+	0xf0, 0x10, 0xf0, 0x10, 0xf0, // 3
+	0x90, 0x90, 0xf0, 0x10, 0x10, // 4
+	0xf0, 0x80, 0xf0, 0x10, 0xf0, // 5
+	0xf0, 0x80, 0xf0, 0x90, 0xf0, // 6
+	0xf0, 0x10, 0x20, 0x40, 0x40, // 7
+	0xf0, 0x90, 0xf0, 0x90, 0xf0, // 8
+	0xf0, 0x90, 0xf0, 0x10, 0xf0, // 9
+	0xf0, 0x90, 0xf0, 0x90, 0x90, // A
+	0xE0, 0x90, 0xE0, 0x90, 0xE0, // B
+	0xf0, 0x80, 0x80, 0x80, 0xf0, // C
+	0xE0, 0x90, 0x90, 0x90, 0xE0, // D
+	0xf0, 0x80, 0xf0, 0x80, 0xf0, // E
+	0xf0, 0x80, 0xf0, 0x80, 0x80, // F
+}
+
+func mapFontset(mem []byte) {
+	copy(mem, fontset)
+}
+
+const (
+	// LargeFontBase is where the SUPER-CHIP/XO-CHIP 8x10 "big" digit font
+	// is mapped, right after the small font.
+	LargeFontBase      = FontSetSize
+	LargeFontGlyphSize = 10
+	// LargeFontSetSize covers digits 0-9; SUPER-CHIP never defined big
+	// glyphs for A-F.
+	LargeFontSetSize = 10 * LargeFontGlyphSize
+)
+
+// largeFont is the SUPER-CHIP/XO-CHIP 8x10 big font, used by Fx30.
+var largeFont = []byte{
+	0x3C, 0x7E, 0xE7, 0xC3, 0xC3, 0xC3, 0xC3, 0xE7, 0x7E, 0x3C, // 0
+	0x18, 0x38, 0x58, 0x18, 0x18, 0x18, 0x18, 0x18, 0x18, 0x3C, // 1
+	0x3E, 0x7F, 0xC3, 0x06, 0x0C, 0x18, 0x30, 0x60, 0xFF, 0xFF, // 2
+	0x3C, 0x7E, 0xC3, 0x03, 0x0E, 0x0E, 0x03, 0xC3, 0x7E, 0x3C, // 3
+	0x06, 0x0E, 0x1E, 0x36, 0x66, 0xC6, 0xFF, 0xFF, 0x06, 0x06, // 4
+	0xFF, 0xFF, 0xC0, 0xC0, 0xFC, 0xFE, 0x03, 0xC3, 0x7E, 0x3C, // 5
+	0x3E, 0x7C, 0xC0, 0xC0, 0xFC, 0xFE, 0xC3, 0xC3, 0x7E, 0x3C, // 6
+	0xFF, 0xFF, 0x03, 0x06, 0x0C, 0x18, 0x30, 0x60, 0x60, 0x60, // 7
+	0x3C, 0x7E, 0xC3, 0xC3, 0x7E, 0x7E, 0xC3, 0xC3, 0x7E, 0x3C, // 8
+	0x3C, 0x7E, 0xC3, 0xC3, 0x7F, 0x3F, 0x03, 0x03, 0x7E, 0x3C, // 9
+}
+
+func mapLargeFont(mem []byte) {
+	copy(mem[LargeFontBase:], largeFont)
+}
@@ -0,0 +1,20 @@
+package chip8
+
+// NoKey is returned by WaitKey when no mapped key is currently held down.
+const NoKey = 0xFF
+
+// Frontend is everything a VM needs from the outside world: input, audio,
+// and video. A CHIP-8 ROM can be driven headlessly by implementing this
+// against a fake, or for real by wrapping a rendering/input library.
+type Frontend interface {
+	// KeyDown reports whether the given CHIP-8 key (0x0-0xF) is held down.
+	KeyDown(key byte) bool
+	// WaitKey returns the first CHIP-8 key currently held down, or NoKey
+	// if none are. It is polled once per cycle by Fx0A, not a blocking call.
+	WaitKey() byte
+	// Beep turns the emulator's tone on or off.
+	Beep(on bool)
+	// Present is called after the framebuffer changes with the pixel
+	// buffer (RGBA, w*h*4 bytes) to display.
+	Present(pixels []byte, w, h int)
+}
@@ -0,0 +1,44 @@
+package chip8
+
+// HeadlessFrontend is a Frontend with no real input/audio/video backend.
+// Tests (and other non-interactive integrations, e.g. CI ROM test rigs)
+// can drive a VM with one directly: set Keys to simulate held keys and
+// inspect Pixels/BeepOn after each Update.
+type HeadlessFrontend struct {
+	// Keys holds the currently "held down" state of each CHIP-8 key (0x0-0xF).
+	Keys [16]bool
+
+	// Pixels is the most recently presented framebuffer.
+	Pixels []byte
+	Width  int
+	Height int
+
+	// BeepOn reflects the last call to Beep.
+	BeepOn bool
+}
+
+func (h *HeadlessFrontend) KeyDown(key byte) bool {
+	if key >= byte(len(h.Keys)) {
+		return false
+	}
+	return h.Keys[key]
+}
+
+func (h *HeadlessFrontend) WaitKey() byte {
+	for key, down := range h.Keys {
+		if down {
+			return byte(key)
+		}
+	}
+	return NoKey
+}
+
+func (h *HeadlessFrontend) Beep(on bool) {
+	h.BeepOn = on
+}
+
+func (h *HeadlessFrontend) Present(pixels []byte, w, height int) {
+	h.Pixels = pixels
+	h.Width = w
+	h.Height = height
+}
@@ -0,0 +1,354 @@
+package chip8
+
+import "math/bits"
+
+// read from memory starting at address in i, store in v[0] through v[x]
+func (g *VM) op_fx65(x byte) error {
+	for i := 0; i <= int(x); i++ {
+		g.v[i] = g.mem[g.i+uint16(i)]
+	}
+	if g.quirks.LoadStoreIncrementsI {
+		g.i += uint16(x) + 1
+	}
+	return nil
+}
+
+// read v[0] through v[x], store in memory starting at address in i
+func (g *VM) op_fx55(x byte) error {
+	i := g.i
+	for j := 0; j <= int(x); j++ {
+		g.mem[i] = g.v[j]
+		i++
+	}
+	if g.quirks.LoadStoreIncrementsI {
+		g.i += uint16(x) + 1
+	}
+	return nil
+}
+
+// store BCD rep of v[x] in i, i+1, and i+2
+func (g *VM) op_fx33(x byte) error {
+	g.mem[g.i] = bcd(g.v[x], 100)
+	g.mem[g.i+1] = bcd(g.v[x], 10)
+	g.mem[g.i+2] = bcd(g.v[x], 1)
+	return nil
+}
+
+// set i to the address of the small font glyph for digit v[x]
+func (g *VM) op_fx29(x byte) error {
+	g.i = uint16(g.v[x]) * 5
+	return nil
+}
+
+// add v[x] to i, store in i
+func (g *VM) op_fx1e(x byte) error {
+	g.i += uint16(g.v[x])
+	return nil
+}
+
+// set sound timer to v[x]
+func (g *VM) op_fx18(x byte) error {
+	g.st = g.v[x]
+	return nil
+}
+
+// set delay timer to v[x]
+func (g *VM) op_fx15(x byte) error {
+	g.dt = g.v[x]
+	return nil
+}
+
+// wait for key press, store in v[x]
+func (g *VM) op_fx0a(x byte) error {
+	key := g.front.WaitKey()
+	if key == NoKey {
+		g.pc -= 2
+	} else {
+		g.v[x] = key
+	}
+	return nil
+}
+
+// set v[x] to delay timer
+func (g *VM) op_fx07(x byte) error {
+	g.v[x] = g.dt
+	return nil
+}
+
+// skip next instruction if key pressed != v[x]
+func (g *VM) op_exa1(x byte) error {
+	if !g.front.KeyDown(g.v[x]) {
+		g.pc += 2
+	}
+	return nil
+}
+
+// skip next instruction if key pressed == v[x]
+func (g *VM) op_ex9e(x byte) error {
+	if g.front.KeyDown(g.v[x]) {
+		g.pc += 2
+	}
+	return nil
+}
+
+// write a sprite to the display. With SuperChip and n==0 this draws a
+// SUPER-CHIP/XO-CHIP 16x16 sprite instead of the usual 8-wide one, and
+// collision in v[f] becomes a row count rather than a single flag, per
+// SUPER-CHIP convention.
+func (g *VM) op_dxyn(x, y, n byte) error {
+	width, height := 8, int(n)
+	if n == 0 && g.quirks.SuperChip {
+		width, height = 16, 16
+	}
+	bytesPerRow := width / 8
+
+	g.v[0xf] = 0
+	rowCollisions := 0
+	dataOffset := g.i
+
+	g.eachSelectedPlane(func(plane []byte) {
+		for row := 0; row < height; row++ {
+			newY := int(g.v[y]) + row
+			if g.quirks.WrapSprites {
+				newY %= g.dispH
+			} else if newY >= g.dispH {
+				continue
+			}
+
+			rowHit := false
+			for b := 0; b < bytesPerRow; b++ {
+				data := g.mem[dataOffset+uint16(row*bytesPerRow+b)]
+				for bit := 0; bit < 8; bit++ {
+					if data&(0x80>>bit) == 0 {
+						continue
+					}
+					newX := int(g.v[x]) + b*8 + bit
+					if g.quirks.WrapSprites {
+						newX %= g.dispW
+					} else if newX >= g.dispW {
+						continue
+					}
+
+					idx := newY*g.dispW + newX
+					if plane[idx] == 1 {
+						rowHit = true
+					}
+					plane[idx] ^= 1
+				}
+			}
+			if rowHit {
+				rowCollisions++
+			}
+		}
+		dataOffset += uint16(height * bytesPerRow)
+	})
+
+	if width == 16 {
+		g.v[0xf] = byte(rowCollisions)
+	} else if rowCollisions > 0 {
+		g.v[0xf] = 1
+	}
+
+	g.present()
+	if g.quirks.DisplayWait {
+		g.haltFrame = true
+	}
+	return nil
+}
+
+// set v[x] to random byte bitwise anded with kk
+func (g *VM) op_cxkk(x, kk byte) error {
+	g.v[x] = byte(g.rng.UintN(255)) & kk
+	return nil
+}
+
+// jump to address nnn + v[0] (or, with JumpUsesVX, xnn + v[x])
+func (g *VM) op_bnnn(x byte, nnn uint16) error {
+	if g.quirks.JumpUsesVX {
+		g.pc = nnn + uint16(g.v[x])
+	} else {
+		g.pc = nnn + uint16(g.v[0])
+	}
+	return nil
+}
+
+// set i = nnn
+func (g *VM) op_annn(nnn uint16) error {
+	g.i = nnn
+	return nil
+}
+
+// skip next instruction if v[x] != v[y]
+func (g *VM) op_9xy0(x, y byte) error {
+	if g.v[x] != g.v[y] {
+		g.pc += 2
+	}
+	return nil
+}
+
+// shift left v[x] (or, with ShiftUsesVY, v[y]) by 1, store in v[x], store
+// most-significant bit in v[f]
+func (g *VM) op_8xye(x, y byte) error {
+	src := g.v[x]
+	if g.quirks.ShiftUsesVY {
+		src = g.v[y]
+	}
+	var most byte
+	if bits.OnesCount8(src) > bits.OnesCount8(src<<1) {
+		most = 1
+	}
+	g.v[x] = src << 1
+	g.v[0xf] = most
+	return nil
+}
+
+// subtract v[x] from v[y], store in v[x], store inverse of borrow in v[f]
+func (g *VM) op_8xy7(x, y byte) error {
+	diff, borrow := bits.Sub(uint(g.v[y]), uint(g.v[x]), 0)
+	g.v[x] = byte(diff)
+	g.v[0xf] = 1 - byte(borrow)
+	return nil
+}
+
+// shift right v[x] (or, with ShiftUsesVY, v[y]) by 1, store in v[x], store
+// least-significant bit in v[f]
+func (g *VM) op_8xy6(x, y byte) error {
+	src := g.v[x]
+	if g.quirks.ShiftUsesVY {
+		src = g.v[y]
+	}
+	var least byte
+	if bits.OnesCount8(src) > bits.OnesCount8(src>>1) {
+		least = 1
+	}
+	g.v[x] = src >> 1
+	g.v[0xf] = least
+	return nil
+}
+
+// subtract v[y] from v[x], store in v[x], store inverse of borrow in v[f]
+func (g *VM) op_8xy5(x, y byte) error {
+	diff, borrow := bits.Sub(uint(g.v[x]), uint(g.v[y]), 0)
+	g.v[x] = byte(diff)
+	g.v[0xf] = 1 - byte(borrow)
+	return nil
+}
+
+// add v[y] to v[x], store in v[x], store carry in v[f]
+func (g *VM) op_8xy4(x, y byte) error {
+	sum := uint16(g.v[x]) + uint16(g.v[y])
+	g.v[x] += g.v[y]
+	g.v[0xf] = byte((sum >> 8) & 1)
+	return nil
+}
+
+// xor v[x] and v[y], store in v[x]
+func (g *VM) op_8xy3(x, y byte) error {
+	g.v[x] ^= g.v[y]
+	if g.quirks.VFResetOnAND {
+		g.v[0xf] = 0
+	}
+	return nil
+}
+
+// bitwise and v[x] and v[y], store in v[x]
+func (g *VM) op_8xy2(x, y byte) error {
+	g.v[x] &= g.v[y]
+	if g.quirks.VFResetOnAND {
+		g.v[0xf] = 0
+	}
+	return nil
+}
+
+// bitwise or v[x] and v[y], store in v[x]
+func (g *VM) op_8xy1(x, y byte) error {
+	g.v[x] |= g.v[y]
+	if g.quirks.VFResetOnAND {
+		g.v[0xf] = 0
+	}
+	return nil
+}
+
+// set v[x] to v[y]
+func (g *VM) op_8xy0(x, y byte) error {
+	g.v[x] = g.v[y]
+	return nil
+}
+
+// add kk to v[x], store in v[x]
+func (g *VM) op_7xkk(x, kk byte) error {
+	g.v[x] += kk
+	return nil
+}
+
+// set v[x] to kk
+func (g *VM) op_6xkk(x, kk byte) error {
+	g.v[x] = kk
+	return nil
+}
+
+// skip next instruction if v[x] == v[y]
+func (g *VM) op_5xy0(x, y byte) error {
+	if g.v[x] == g.v[y] {
+		g.pc += 2
+	}
+	return nil
+}
+
+// skip next instruction if v[x] != kk
+func (g *VM) op_4xkk(x, kk byte) error {
+	if g.v[x] != kk {
+		g.pc += 2
+	}
+	return nil
+}
+
+// skip next instruction if v[x] == kk
+func (g *VM) op_3xkk(x, kk byte) error {
+	if g.v[x] == kk {
+		g.pc += 2
+	}
+	return nil
+}
+
+// push address nnn onto stack
+func (g *VM) op_2nnn(nnn uint16) error {
+	if g.sp >= MaxStack {
+		return ErrStackIsFull
+	}
+
+	g.stack[g.sp] = g.pc
+	g.sp++
+	g.pc = nnn
+	return nil
+}
+
+// jump to address nnn
+func (g *VM) op_1nnn(nnn uint16) error {
+	g.pc = nnn
+	return nil
+}
+
+// pop address from stack
+func (g *VM) op_00ee() error {
+	if g.sp == 0 {
+		return ErrStackIsEmpty
+	}
+
+	g.pc = g.stack[g.sp-1]
+	g.sp--
+	return nil
+}
+
+// clear display
+func (g *VM) op_00e0() error {
+	for p := range g.planes {
+		clear(g.planes[p])
+	}
+	g.present()
+	return nil
+}
+
+func bcd(num, place byte) byte {
+	return ((num % (place * 10)) - (num % place)) / place
+}
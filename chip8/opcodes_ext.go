@@ -0,0 +1,148 @@
+package chip8
+
+import "os"
+
+// rplFlagsPath returns where Fx75/Fx85 persist the "RPL user flags" (named
+// for the HP-48 calculator SUPER-CHIP was first written for) for the
+// currently loaded ROM: the ROM path with ".rpl" appended, the same
+// convention cmd/chippy uses for save-state slots (ROM path + ".state").
+// Scoping the file to the ROM keeps switching ROMs from silently
+// clobbering or loading another ROM's persisted flag registers.
+func (g *VM) rplFlagsPath() string {
+	return g.romPath + ".rpl"
+}
+
+// scroll display down n pixel rows (SUPER-CHIP)
+func (g *VM) op_00cn(n byte) error {
+	g.scrollDown(int(n))
+	g.present()
+	return nil
+}
+
+// scroll display up n pixel rows (XO-CHIP)
+func (g *VM) op_00dn(n byte) error {
+	g.scrollUp(int(n))
+	g.present()
+	return nil
+}
+
+// scroll display right 4 pixels (SUPER-CHIP)
+func (g *VM) op_00fb() error {
+	g.scrollRight()
+	g.present()
+	return nil
+}
+
+// scroll display left 4 pixels (SUPER-CHIP)
+func (g *VM) op_00fc() error {
+	g.scrollLeft()
+	g.present()
+	return nil
+}
+
+// exit the interpreter (SUPER-CHIP)
+func (g *VM) op_00fd() error {
+	return ErrExited
+}
+
+// switch to lo-res (64x32) display mode (SUPER-CHIP)
+func (g *VM) op_00fe() error {
+	g.resizeDisplay(false)
+	g.present()
+	return nil
+}
+
+// switch to hi-res (128x64) display mode (SUPER-CHIP)
+func (g *VM) op_00ff() error {
+	g.resizeDisplay(true)
+	g.present()
+	return nil
+}
+
+// store v[x] through v[y] (inclusive, in either direction) to memory
+// starting at i; i is left unchanged (XO-CHIP)
+func (g *VM) op_5xy2(x, y byte) error {
+	addr := g.i
+	g.forEachInRange(x, y, func(r byte) {
+		g.mem[addr] = g.v[r]
+		addr++
+	})
+	return nil
+}
+
+// load v[x] through v[y] (inclusive, in either direction) from memory
+// starting at i; i is left unchanged (XO-CHIP)
+func (g *VM) op_5xy3(x, y byte) error {
+	addr := g.i
+	g.forEachInRange(x, y, func(r byte) {
+		g.v[r] = g.mem[addr]
+		addr++
+	})
+	return nil
+}
+
+func (g *VM) forEachInRange(x, y byte, fn func(r byte)) {
+	if x <= y {
+		for r := x; r <= y; r++ {
+			fn(r)
+		}
+		return
+	}
+	for r := x; ; r-- {
+		fn(r)
+		if r == y {
+			break
+		}
+	}
+}
+
+// load the next two fetched bytes into i as a 16-bit address (XO-CHIP)
+func (g *VM) op_f000() error {
+	g.i = g.fetch()
+	return nil
+}
+
+// select which of the two bitplanes subsequent draw/scroll opcodes affect;
+// x itself is the plane bitmask, not a register index (XO-CHIP)
+func (g *VM) op_fx01(x byte) error {
+	g.drawPlanes = x & 0x3
+	return nil
+}
+
+// load the XO-CHIP audio pattern buffer (16 bytes) from memory at i
+func (g *VM) op_fx02() error {
+	copy(g.xoAudio[:], g.mem[g.i:g.i+16])
+	return nil
+}
+
+// set i to the address of the big font glyph for digit v[x] (SUPER-CHIP)
+func (g *VM) op_fx30(x byte) error {
+	g.i = LargeFontBase + uint16(g.v[x])*LargeFontGlyphSize
+	return nil
+}
+
+// set the XO-CHIP audio playback pitch from v[x]
+func (g *VM) op_fx3a(x byte) error {
+	g.xoPitch = g.v[x]
+	return nil
+}
+
+// save v[0] through v[x] to the RPL flags file (SUPER-CHIP)
+func (g *VM) op_fx75(x byte) error {
+	copy(g.rpl[:], g.v[:int(x)+1])
+	return os.WriteFile(g.rplFlagsPath(), g.rpl[:int(x)+1], 0o644)
+}
+
+// load v[0] through v[x] from the RPL flags file (SUPER-CHIP)
+func (g *VM) op_fx85(x byte) error {
+	data, err := os.ReadFile(g.rplFlagsPath())
+	if err != nil {
+		return err
+	}
+	n := int(x) + 1
+	if len(data) < n {
+		n = len(data)
+	}
+	copy(g.v[:n], data[:n])
+	return nil
+}
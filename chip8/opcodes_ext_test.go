@@ -0,0 +1,125 @@
+package chip8
+
+import (
+	"os"
+	"testing"
+)
+
+func TestOp00ffSwitchesToHiRes(t *testing.T) {
+	rom := romWithProgram(t, []byte{0x00, 0xFF})
+	front := &HeadlessFrontend{}
+
+	vm, err := NewVM(front, QuirksSCHIP, rom)
+	if err != nil {
+		t.Fatalf("NewVM: %v", err)
+	}
+	if err := vm.Update(1); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if front.Width != DispWidth*2 || front.Height != DispHeight*2 {
+		t.Fatalf("Present called with %dx%d, want %dx%d", front.Width, front.Height, DispWidth*2, DispHeight*2)
+	}
+}
+
+func TestOp00ffRejectedWithoutSuperChipQuirk(t *testing.T) {
+	rom := romWithProgram(t, []byte{0x00, 0xFF})
+	front := &HeadlessFrontend{}
+
+	vm, err := NewVM(front, Quirks{}, rom)
+	if err != nil {
+		t.Fatalf("NewVM: %v", err)
+	}
+	if err := vm.Update(1); err != ErrNotValidInstruction {
+		t.Fatalf("Update err = %v, want ErrNotValidInstruction", err)
+	}
+}
+
+func TestOp00fdExits(t *testing.T) {
+	rom := romWithProgram(t, []byte{0x00, 0xFD})
+	front := &HeadlessFrontend{}
+
+	vm, err := NewVM(front, QuirksSCHIP, rom)
+	if err != nil {
+		t.Fatalf("NewVM: %v", err)
+	}
+	if err := vm.Update(1); err != ErrExited {
+		t.Fatalf("Update err = %v, want ErrExited", err)
+	}
+}
+
+func TestRPLFlagsAreScopedPerROM(t *testing.T) {
+	// Fx75 V0: save v0 to the RPL flags file.
+	romA := romWithProgram(t, []byte{0x60, 0x11, 0xF0, 0x75})
+	romB := romWithProgram(t, []byte{0x60, 0x22, 0xF0, 0x75})
+	front := &HeadlessFrontend{}
+
+	vmA, err := NewVM(front, QuirksSCHIP, romA)
+	if err != nil {
+		t.Fatalf("NewVM: %v", err)
+	}
+	if err := vmA.Update(2); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	vmB, err := NewVM(front, QuirksSCHIP, romB)
+	if err != nil {
+		t.Fatalf("NewVM: %v", err)
+	}
+	if err := vmB.Update(2); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if _, err := os.Stat(romA + ".rpl"); err != nil {
+		t.Fatalf("romA's RPL flags file missing: %v", err)
+	}
+	if _, err := os.Stat(romB + ".rpl"); err != nil {
+		t.Fatalf("romB's RPL flags file missing: %v", err)
+	}
+
+	// Fx85 V0: load v0 back from the RPL flags file; each ROM must read
+	// back its own v0, not the other ROM's.
+	loadA := romWithProgram(t, []byte{0xF0, 0x85})
+	vmA2, err := NewVM(front, QuirksSCHIP, loadA)
+	if err != nil {
+		t.Fatalf("NewVM: %v", err)
+	}
+	vmA2.romPath = romA
+	if err := vmA2.Update(1); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if vmA2.v[0] != 0x11 {
+		t.Fatalf("v0 = %#02x after loading romA's flags, want 0x11", vmA2.v[0])
+	}
+}
+
+func TestOpDxy0Draws16x16Sprite(t *testing.T) {
+	program := []byte{
+		0xA2, 0x0A, // i = 0x20A, just past the program
+		0xD0, 0x10, // draw 16x16 sprite at (v0, v1) = (0,0)
+	}
+	for len(program) < 10 {
+		program = append(program, 0)
+	}
+	sprite := make([]byte, 32) // 16 rows x 2 bytes, all bits set
+	for i := range sprite {
+		sprite[i] = 0xFF
+	}
+	program = append(program, sprite...)
+
+	rom := romWithProgram(t, program)
+	front := &HeadlessFrontend{}
+
+	vm, err := NewVM(front, QuirksSCHIP, rom)
+	if err != nil {
+		t.Fatalf("NewVM: %v", err)
+	}
+	if err := vm.Update(2); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if vm.v[0xf] != 0 {
+		t.Fatalf("v[f] = %d, want 0 (no collision drawing onto a blank display)", vm.v[0xf])
+	}
+	if front.Pixels[0] != 0xFF {
+		t.Fatalf("top-left pixel not drawn")
+	}
+}
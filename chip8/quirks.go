@@ -0,0 +1,154 @@
+package chip8
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Quirks captures the places where real CHIP-8 interpreters disagree.
+// Different generations of hardware and software (COSMAC VIP, SUPER-CHIP,
+// XO-CHIP) settled on different behavior for the same opcodes, and a ROM
+// written for one will glitch or hang under another's rules.
+type Quirks struct {
+	// ShiftUsesVY makes 8xy6/8xyE shift v[y] into v[x] before shifting,
+	// as the original COSMAC VIP interpreter did. When false (SUPER-CHIP
+	// and later), the opcodes shift v[x] in place and ignore v[y].
+	ShiftUsesVY bool
+	// LoadStoreIncrementsI makes Fx55/Fx65 advance i by x+1 after the
+	// transfer, as the COSMAC VIP did. SUPER-CHIP leaves i unchanged.
+	LoadStoreIncrementsI bool
+	// JumpUsesVX makes Bnnn jump to xnn+v[x] (the high nibble selects the
+	// register) instead of nnn+v[0], matching SUPER-CHIP's Bxnn.
+	JumpUsesVX bool
+	// WrapSprites makes Dxyn sprites wrap around the edges of the display
+	// instead of being clipped at the edge.
+	WrapSprites bool
+	// VFResetOnAND makes 8xy1/8xy2/8xy3 (OR/AND/XOR) reset v[0xF] to 0,
+	// a COSMAC VIP side effect that SUPER-CHIP and XO-CHIP dropped.
+	VFResetOnAND bool
+	// DisplayWait makes Dxyn consume the rest of the current frame's
+	// cycle budget, mirroring the VIP's wait for the vertical blank
+	// interrupt before drawing.
+	DisplayWait bool
+
+	// SuperChip enables SUPER-CHIP's opcodes: hi-res mode (00FE/00FF),
+	// scroll (00Cn/00FB/00FC), exit (00FD), 16x16 sprites (Dxy0), the big
+	// font (Fx30), and RPL flag persistence (Fx75/Fx85).
+	SuperChip bool
+	// XOChip enables XO-CHIP's opcodes on top of SuperChip's: scroll up
+	// (00Dn), register range save/load (5xy2/5xy3), 16-bit i load
+	// (F000 nnnn), and the bitplane/audio opcodes (Fx01/Fx02/Fx3A).
+	XOChip bool
+}
+
+// QuirksCOSMAC matches the original COSMAC VIP interpreter.
+var QuirksCOSMAC = Quirks{
+	ShiftUsesVY:          true,
+	LoadStoreIncrementsI: true,
+	WrapSprites:          false,
+	VFResetOnAND:         true,
+	DisplayWait:          true,
+}
+
+// QuirksSCHIP matches SUPER-CHIP 1.1.
+var QuirksSCHIP = Quirks{
+	JumpUsesVX:  true,
+	WrapSprites: false,
+	SuperChip:   true,
+}
+
+// QuirksXOCHIP matches XO-CHIP.
+var QuirksXOCHIP = Quirks{
+	WrapSprites: true,
+	SuperChip:   true,
+	XOChip:      true,
+}
+
+// ParseQuirksPreset resolves a -quirks flag value to a preset.
+func ParseQuirksPreset(name string) (Quirks, error) {
+	switch name {
+	case "vip", "cosmac":
+		return QuirksCOSMAC, nil
+	case "schip", "superchip":
+		return QuirksSCHIP, nil
+	case "xochip":
+		return QuirksXOCHIP, nil
+	default:
+		return Quirks{}, fmt.Errorf("chip8: unknown quirks preset %q", name)
+	}
+}
+
+// QuirksOverride is a partial set of Quirks fields for one ROM, as parsed
+// from a quirks overrides file. A nil field means "inherit the selected
+// preset"; only fields present in the JSON object are set. Field names
+// mirror Quirks.
+type QuirksOverride struct {
+	ShiftUsesVY          *bool
+	LoadStoreIncrementsI *bool
+	JumpUsesVX           *bool
+	WrapSprites          *bool
+	VFResetOnAND         *bool
+	DisplayWait          *bool
+	SuperChip            *bool
+	XOChip               *bool
+}
+
+// LoadQuirksOverrides reads a JSON file mapping ROM file names (matched by
+// filepath.Base) to partial quirks overrides, for ROMs that need to flip a
+// handful of fields away from the selected preset without restating the
+// rest of it. A missing file is not an error; it is treated as no
+// overrides.
+func LoadQuirksOverrides(path string) (map[string]QuirksOverride, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides map[string]QuirksOverride
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("chip8: parsing quirks overrides %s: %w", path, err)
+	}
+	return overrides, nil
+}
+
+// QuirksFor returns the quirks to use for rom: base (the selected preset)
+// with any fields named in overrides (keyed by base file name) replaced.
+// Fields the override doesn't mention keep base's value.
+func QuirksFor(base Quirks, rom string, overrides map[string]QuirksOverride) Quirks {
+	o, ok := overrides[filepath.Base(rom)]
+	if !ok {
+		return base
+	}
+
+	q := base
+	if o.ShiftUsesVY != nil {
+		q.ShiftUsesVY = *o.ShiftUsesVY
+	}
+	if o.LoadStoreIncrementsI != nil {
+		q.LoadStoreIncrementsI = *o.LoadStoreIncrementsI
+	}
+	if o.JumpUsesVX != nil {
+		q.JumpUsesVX = *o.JumpUsesVX
+	}
+	if o.WrapSprites != nil {
+		q.WrapSprites = *o.WrapSprites
+	}
+	if o.VFResetOnAND != nil {
+		q.VFResetOnAND = *o.VFResetOnAND
+	}
+	if o.DisplayWait != nil {
+		q.DisplayWait = *o.DisplayWait
+	}
+	if o.SuperChip != nil {
+		q.SuperChip = *o.SuperChip
+	}
+	if o.XOChip != nil {
+		q.XOChip = *o.XOChip
+	}
+	return q
+}
@@ -0,0 +1,65 @@
+package chip8
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseQuirksPresetUnknown(t *testing.T) {
+	if _, err := ParseQuirksPreset("atari"); err == nil {
+		t.Fatalf("expected error for unknown preset")
+	}
+}
+
+func TestQuirksForAppliesOverride(t *testing.T) {
+	trueVal := true
+	overrides := map[string]QuirksOverride{
+		"game.ch8": {ShiftUsesVY: &trueVal},
+	}
+
+	// Only ShiftUsesVY is named, so the rest of the SCHIP preset
+	// (SuperChip, JumpUsesVX) must survive into the result, not revert to
+	// the zero value.
+	want := QuirksSCHIP
+	want.ShiftUsesVY = true
+	got := QuirksFor(QuirksSCHIP, "/roms/game.ch8", overrides)
+	if got != want {
+		t.Fatalf("QuirksFor = %+v, want %+v", got, want)
+	}
+
+	got = QuirksFor(QuirksSCHIP, "/roms/other.ch8", overrides)
+	if got != QuirksSCHIP {
+		t.Fatalf("QuirksFor = %+v, want QuirksSCHIP", got)
+	}
+}
+
+func TestLoadQuirksOverridesMissingFileIsNotError(t *testing.T) {
+	overrides, err := LoadQuirksOverrides(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("LoadQuirksOverrides: %v", err)
+	}
+	if overrides != nil {
+		t.Fatalf("overrides = %+v, want nil", overrides)
+	}
+}
+
+func TestLoadQuirksOverridesParsesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quirks.json")
+	const body = `{"game.ch8": {"ShiftUsesVY": true}}`
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("write overrides file: %v", err)
+	}
+
+	overrides, err := LoadQuirksOverrides(path)
+	if err != nil {
+		t.Fatalf("LoadQuirksOverrides: %v", err)
+	}
+	shift := overrides["game.ch8"].ShiftUsesVY
+	if shift == nil || !*shift {
+		t.Fatalf("overrides[game.ch8].ShiftUsesVY = %v, want pointer to true", shift)
+	}
+	if overrides["game.ch8"].SuperChip != nil {
+		t.Fatalf("overrides[game.ch8].SuperChip = %v, want nil (not mentioned in the JSON)", overrides["game.ch8"].SuperChip)
+	}
+}
@@ -0,0 +1,109 @@
+package chip8
+
+import (
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"math/rand/v2"
+)
+
+const (
+	stateMagic   = "CHPY"
+	stateVersion = 1
+)
+
+// state is the gob-encoded payload of a save state, following the magic and
+// version header. It covers the VM's memory, registers, timers, display,
+// the quirks in effect, and the RNG seed. Only the seed is saved, not the
+// RNG's position: LoadState restarts the "random" byte sequence (Cxkk)
+// from that seed, so every load of the same state replays the same bytes,
+// but not the bytes the saving run would have drawn next.
+type state struct {
+	Mem        []byte
+	V          []byte
+	I          uint16
+	PC         uint16
+	SP         uint16
+	Stack      []uint16
+	DT, ST     byte
+	HiRes      bool
+	Planes     [2][]byte
+	DrawPlanes byte
+	RPL        [MaxRegs]byte
+	XOAudio    [16]byte
+	XOPitch    byte
+	Quirks     Quirks
+	RNGSeed1   uint64
+	RNGSeed2   uint64
+}
+
+// SaveState writes a snapshot of the VM to w: a 4-byte magic, a uint16
+// format version, then the gob-encoded state. LoadState reverses this
+// exactly, so a saved state can be shared as a reproducible bug report or
+// reloaded to resume play.
+func (g *VM) SaveState(w io.Writer) error {
+	if _, err := io.WriteString(w, stateMagic); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint16(stateVersion)); err != nil {
+		return err
+	}
+
+	return gob.NewEncoder(w).Encode(&state{
+		Mem:        g.mem,
+		V:          g.v,
+		I:          g.i,
+		PC:         g.pc,
+		SP:         g.sp,
+		Stack:      g.stack,
+		DT:         g.dt,
+		ST:         g.st,
+		HiRes:      g.hiRes,
+		Planes:     g.planes,
+		DrawPlanes: g.drawPlanes,
+		RPL:        g.rpl,
+		XOAudio:    g.xoAudio,
+		XOPitch:    g.xoPitch,
+		Quirks:     g.quirks,
+		RNGSeed1:   g.rngSeed1,
+		RNGSeed2:   g.rngSeed2,
+	})
+}
+
+// LoadState restores a VM to the snapshot previously written by SaveState.
+func (g *VM) LoadState(r io.Reader) error {
+	magic := make([]byte, len(stateMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return err
+	}
+	if string(magic) != stateMagic {
+		return fmt.Errorf("chip8: not a chippy save state")
+	}
+
+	var version uint16
+	if err := binary.Read(r, binary.BigEndian, &version); err != nil {
+		return err
+	}
+	if version != stateVersion {
+		return fmt.Errorf("chip8: unsupported save state version %d", version)
+	}
+
+	var s state
+	if err := gob.NewDecoder(r).Decode(&s); err != nil {
+		return err
+	}
+
+	g.mem, g.v, g.i, g.pc, g.sp, g.stack = s.Mem, s.V, s.I, s.PC, s.SP, s.Stack
+	g.dt, g.st = s.DT, s.ST
+	g.resizeDisplay(s.HiRes)
+	g.planes = s.Planes
+	g.drawPlanes = s.DrawPlanes
+	g.rpl, g.xoAudio, g.xoPitch = s.RPL, s.XOAudio, s.XOPitch
+	g.quirks = s.Quirks
+	g.rngSeed1, g.rngSeed2 = s.RNGSeed1, s.RNGSeed2
+	g.rng = rand.New(rand.NewPCG(g.rngSeed1, g.rngSeed2))
+	g.present()
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package chip8
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSaveStateLoadStateRoundTrip(t *testing.T) {
+	rom := romWithProgram(t, []byte{0x61, 0x2A}) // 6xkk: v1 = 0x2A
+	front := &HeadlessFrontend{}
+
+	vm, err := NewVM(front, QuirksSCHIP, rom)
+	if err != nil {
+		t.Fatalf("NewVM: %v", err)
+	}
+	if err := vm.Update(1); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	vm.i, vm.sp, vm.dt, vm.st = 0x300, 1, 10, 20
+	vm.stack[0] = 0x250
+
+	var buf bytes.Buffer
+	if err := vm.SaveState(&buf); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+
+	restored, err := NewVM(front, Quirks{}, rom)
+	if err != nil {
+		t.Fatalf("NewVM: %v", err)
+	}
+	if err := restored.LoadState(&buf); err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+
+	if restored.v[1] != vm.v[1] {
+		t.Fatalf("v[1] = %#x, want %#x", restored.v[1], vm.v[1])
+	}
+	if restored.i != vm.i || restored.sp != vm.sp || restored.dt != vm.dt || restored.st != vm.st {
+		t.Fatalf("restored i/sp/dt/st = %d/%d/%d/%d, want %d/%d/%d/%d",
+			restored.i, restored.sp, restored.dt, restored.st, vm.i, vm.sp, vm.dt, vm.st)
+	}
+	if restored.stack[0] != vm.stack[0] {
+		t.Fatalf("stack[0] = %#x, want %#x", restored.stack[0], vm.stack[0])
+	}
+	if restored.quirks != vm.quirks {
+		t.Fatalf("restored quirks = %+v, want %+v", restored.quirks, vm.quirks)
+	}
+}
+
+func TestLoadStateRejectsBadMagic(t *testing.T) {
+	rom := romWithProgram(t, []byte{0x00, 0xE0})
+	front := &HeadlessFrontend{}
+
+	vm, err := NewVM(front, Quirks{}, rom)
+	if err != nil {
+		t.Fatalf("NewVM: %v", err)
+	}
+	if err := vm.LoadState(bytes.NewReader([]byte("NOPE"))); err == nil {
+		t.Fatalf("LoadState with bad magic: want error, got nil")
+	}
+}
@@ -0,0 +1,58 @@
+package chip8
+
+import "testing"
+
+func TestTimersStayAtZeroInsteadOfResetting(t *testing.T) {
+	// 00E0 (inert), then 1200 jumps back to 0x200 so repeated Update calls
+	// keep re-running valid instructions instead of fetching off the end
+	// of the loaded program.
+	rom := romWithProgram(t, []byte{0x00, 0xE0, 0x12, 0x00})
+	front := &HeadlessFrontend{}
+
+	vm, err := NewVM(front, Quirks{}, rom)
+	if err != nil {
+		t.Fatalf("NewVM: %v", err)
+	}
+	vm.dt, vm.st = 1, 1
+
+	if err := vm.Update(1); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if vm.dt != 0 || vm.st != 0 {
+		t.Fatalf("dt=%d st=%d after reaching zero, want both 0", vm.dt, vm.st)
+	}
+
+	if err := vm.Update(1); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if vm.dt != 0 || vm.st != 0 {
+		t.Fatalf("dt=%d st=%d stayed at zero across another Update, want both still 0", vm.dt, vm.st)
+	}
+}
+
+func TestUpdateBeepsFrontendWhileSoundTimerIsPositive(t *testing.T) {
+	// See TestTimersStayAtZeroInsteadOfResetting for why this loops back
+	// on itself instead of running off the end of the program.
+	rom := romWithProgram(t, []byte{0x00, 0xE0, 0x12, 0x00})
+	front := &HeadlessFrontend{}
+
+	vm, err := NewVM(front, Quirks{}, rom)
+	if err != nil {
+		t.Fatalf("NewVM: %v", err)
+	}
+	vm.st = 2
+
+	if err := vm.Update(1); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if !front.BeepOn {
+		t.Fatalf("BeepOn = false while st > 0, want true")
+	}
+
+	if err := vm.Update(1); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if front.BeepOn {
+		t.Fatalf("BeepOn = true after st reached 0, want false")
+	}
+}
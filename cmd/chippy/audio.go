@@ -0,0 +1,36 @@
+package main
+
+import "math"
+
+const SampleRate = 44100
+
+// squareWave streams an endless square wave at freq Hz as signed 16-bit
+// stereo PCM, the format ebiten/v2/audio.Player expects.
+type squareWave struct {
+	freq float64
+	pos  int64
+}
+
+func newSquareWave(freq float64) *squareWave {
+	return &squareWave{freq: freq}
+}
+
+func (s *squareWave) Read(buf []byte) (int, error) {
+	const bytesPerSample = 4 // 16-bit stereo: 2 bytes/channel * 2 channels
+	samples := len(buf) / bytesPerSample
+
+	for i := 0; i < samples; i++ {
+		t := float64(s.pos) / float64(SampleRate)
+		v := int16(-32768)
+		if math.Mod(t*s.freq, 1) < 0.5 {
+			v = 32767
+		}
+		buf[i*4] = byte(v)
+		buf[i*4+1] = byte(v >> 8)
+		buf[i*4+2] = byte(v)
+		buf[i*4+3] = byte(v >> 8)
+		s.pos++
+	}
+
+	return samples * bytesPerSample, nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/technologyfreak/chippy/chip8"
+)
+
+// Debugger drives Game from an interactive stdin prompt: stepping,
+// breakpoints, a watchpoint, and register/memory inspection. It runs in
+// its own goroutine, reading one line at a time, so it never blocks
+// ebiten's Update/Draw loop. Every command it issues is funneled through
+// Game.debugReqs and executed on the ebiten goroutine (see
+// Game.runOnGameLoop), since chip8.VM is not safe for concurrent access.
+type Debugger struct {
+	game *Game
+}
+
+// debugRequest is one unit of work the debugger goroutine asks the ebiten
+// goroutine to run against the VM, plus a channel signaling completion.
+type debugRequest struct {
+	fn   func()
+	done chan struct{}
+}
+
+// NewDebugger starts the VM halted, so the emulator waits at the prompt
+// before executing the ROM's first instruction.
+func NewDebugger(game *Game) *Debugger {
+	game.vm.Halt()
+	return &Debugger{game: game}
+}
+
+// Run reads commands from stdin until EOF. Call it in its own goroutine.
+func (d *Debugger) Run() {
+	fmt.Println("chippy debugger: type 'help' for commands")
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		d.printState()
+		fmt.Print("(chippy) ")
+		if !scanner.Scan() {
+			return
+		}
+		d.dispatch(strings.Fields(scanner.Text()))
+	}
+}
+
+func (d *Debugger) dispatch(fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+	switch fields[0] {
+	case "s", "step":
+		if err := d.game.Step(); err != nil {
+			fmt.Println("error:", err)
+		}
+	case "c", "continue":
+		if err := d.game.Continue(); err != nil {
+			fmt.Println("error:", err)
+		}
+	case "b", "break":
+		if addr, ok := parseAddr(fields, 1); ok {
+			d.game.SetBreakpoint(addr)
+		}
+	case "d", "delete":
+		if addr, ok := parseAddr(fields, 1); ok {
+			d.game.ClearBreakpoint(addr)
+		}
+	case "w", "watch":
+		if addr, ok := parseAddr(fields, 1); ok {
+			d.game.Watch(addr)
+		}
+	case "q", "quit":
+		os.Exit(0)
+	case "help":
+		fmt.Println("s[tep]            execute one instruction")
+		fmt.Println("c[ontinue]        resume execution")
+		fmt.Println("b[reak] <addr>    set a breakpoint")
+		fmt.Println("d[elete] <addr>   clear a breakpoint")
+		fmt.Println("w[atch] <addr>    break when mem[addr] changes")
+		fmt.Println("q[uit]            exit chippy")
+	default:
+		fmt.Printf("unknown command %q (try 'help')\n", fields[0])
+	}
+}
+
+func parseAddr(fields []string, i int) (uint16, bool) {
+	if i >= len(fields) {
+		fmt.Println("usage: <command> <hex address>")
+		return 0, false
+	}
+	addr, err := strconv.ParseUint(strings.TrimPrefix(fields[i], "0x"), 16, 16)
+	if err != nil {
+		fmt.Printf("bad address %q: %v\n", fields[i], err)
+		return 0, false
+	}
+	return uint16(addr), true
+}
+
+func (d *Debugger) printState() {
+	snap := d.game.DebugSnapshot()
+	s := snap.State
+	asm, _ := chip8.Disassemble(snap.Mem, s.PC)
+	fmt.Printf("PC=%04X  I=%04X  SP=%d  DT=%d  ST=%d  halted=%v\n", s.PC, s.I, s.SP, s.DT, s.ST, s.Halted)
+	for i, v := range s.V {
+		fmt.Printf("V%X=%02X ", i, v)
+	}
+	fmt.Println()
+	fmt.Printf("%04X: %s\n", s.PC, asm)
+}
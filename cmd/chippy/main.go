@@ -0,0 +1,325 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/technologyfreak/chippy/chip8"
+)
+
+const (
+	DispScale      = 10
+	CyclesPerFrame = 20
+	DefaultBeepHz  = 440
+)
+
+var DispOps ebiten.DrawImageOptions
+
+// Game adapts a chip8.VM to ebiten.Game and implements chip8.Frontend using
+// ebiten for rendering, input, and audio.
+type Game struct {
+	vm      *chip8.VM
+	keymap  map[ebiten.Key]byte
+	disp    *ebiten.Image
+	beep    *audio.Player
+	romPath string
+
+	// debugReqs funnels debugger commands (see debug.go) from the stdin
+	// prompt's own goroutine onto the ebiten goroutine. chip8.VM has no
+	// locking of its own, so it is only ever touched from inside Update.
+	debugReqs chan debugRequest
+}
+
+// stateSlotPath returns the save-state file a ROM's F5/F9 hotkeys load
+// and save to: the ROM path with ".state" appended.
+func (g *Game) stateSlotPath() string {
+	return g.romPath + ".state"
+}
+
+// SaveState writes the VM's current state to w.
+func (g *Game) SaveState(w io.Writer) error {
+	return g.vm.SaveState(w)
+}
+
+// LoadState restores the VM from a state previously written by SaveState.
+func (g *Game) LoadState(r io.Reader) error {
+	return g.vm.LoadState(r)
+}
+
+// Step, Continue, SetBreakpoint, ClearBreakpoint, and Watch expose the VM's
+// debugger controls (see chip8.VM) on Game, for the -debug prompt in
+// debug.go. The debugger prompt runs on its own goroutine, so each of
+// these hands its work to runOnGameLoop rather than touching g.vm
+// directly, to avoid racing the 60Hz Update/cycles loop.
+
+func (g *Game) Step() error {
+	var err error
+	g.runOnGameLoop(func() { err = g.vm.Step() })
+	return err
+}
+
+func (g *Game) Continue() error {
+	var err error
+	g.runOnGameLoop(func() { err = g.vm.Continue() })
+	return err
+}
+
+func (g *Game) SetBreakpoint(addr uint16) {
+	g.runOnGameLoop(func() { g.vm.SetBreakpoint(addr) })
+}
+
+func (g *Game) ClearBreakpoint(addr uint16) {
+	g.runOnGameLoop(func() { g.vm.ClearBreakpoint(addr) })
+}
+
+func (g *Game) Watch(addr uint16) {
+	g.runOnGameLoop(func() { g.vm.Watch(addr) })
+}
+
+// DebugSnapshot returns a consistent copy of the VM's registers, control
+// state, and memory for the debugger prompt to render. It is taken on the
+// ebiten goroutine, and memory is copied rather than returned as the live
+// slice from vm.Mem, so the debugger can read it after runOnGameLoop
+// returns without racing the next frame's Update.
+func (g *Game) DebugSnapshot() DebugSnapshot {
+	var snap DebugSnapshot
+	g.runOnGameLoop(func() {
+		snap.State = g.vm.State()
+		mem := g.vm.Mem()
+		snap.Mem = make([]byte, len(mem))
+		copy(snap.Mem, mem)
+	})
+	return snap
+}
+
+// DebugSnapshot is a point-in-time copy of VM state safe to read from any
+// goroutine, returned by Game.DebugSnapshot.
+type DebugSnapshot struct {
+	State chip8.DebugState
+	Mem   []byte
+}
+
+// runOnGameLoop hands fn to Update, which runs it on the ebiten goroutine,
+// and blocks the caller until it has run.
+func (g *Game) runOnGameLoop(fn func()) {
+	done := make(chan struct{})
+	g.debugReqs <- debugRequest{fn: fn, done: done}
+	<-done
+}
+
+func NewGame(quirks chip8.Quirks, beepHz float64, program string, start ...uint16) (*Game, error) {
+	beep, err := audio.NewContext(SampleRate).NewPlayer(newSquareWave(beepHz))
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Game{
+		keymap:    ebitenKeymap(),
+		disp:      ebiten.NewImage(chip8.DispWidth, chip8.DispHeight),
+		beep:      beep,
+		romPath:   program,
+		debugReqs: make(chan debugRequest),
+	}
+
+	vm, err := chip8.NewVM(g, quirks, program, start...)
+	if err != nil {
+		return nil, err
+	}
+	g.vm = vm
+
+	return g, nil
+}
+
+func (g *Game) KeyDown(key byte) bool {
+	for ek, ck := range g.keymap {
+		if ck == key && ebiten.IsKeyPressed(ek) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Game) WaitKey() byte {
+	keys := make([]ebiten.Key, 0)
+	keys = inpututil.AppendPressedKeys(keys)
+	for _, key := range keys {
+		if pressed, valid := g.keymap[key]; valid {
+			return pressed
+		}
+	}
+	return chip8.NoKey
+}
+
+func (g *Game) Beep(on bool) {
+	if on {
+		if !g.beep.IsPlaying() {
+			g.beep.Play()
+		}
+		return
+	}
+	g.beep.Pause()
+}
+
+func (g *Game) Present(pixels []byte, w, h int) {
+	if b := g.disp.Bounds(); b.Dx() != w || b.Dy() != h {
+		g.disp = ebiten.NewImage(w, h)
+	}
+	g.disp.WritePixels(pixels)
+}
+
+func (g *Game) Update() error {
+	g.drainDebugRequests()
+	if inpututil.IsKeyJustPressed(ebiten.KeyF5) {
+		if err := g.saveStateToSlot(); err != nil {
+			fmt.Fprintf(os.Stderr, "save state: %v\n", err)
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF9) {
+		if err := g.loadStateFromSlot(); err != nil {
+			fmt.Fprintf(os.Stderr, "load state: %v\n", err)
+		}
+	}
+	return g.vm.Update(CyclesPerFrame)
+}
+
+// drainDebugRequests runs every debugger command queued since the last
+// frame on the ebiten goroutine, so debugger commands never touch the VM
+// concurrently with the cycles loop below.
+func (g *Game) drainDebugRequests() {
+	for {
+		select {
+		case req := <-g.debugReqs:
+			req.fn()
+			close(req.done)
+		default:
+			return
+		}
+	}
+}
+
+func (g *Game) saveStateToSlot() error {
+	f, err := os.Create(g.stateSlotPath())
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return g.SaveState(f)
+}
+
+func (g *Game) loadStateFromSlot() error {
+	f, err := os.Open(g.stateSlotPath())
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return g.LoadState(f)
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	screen.Clear()
+	screen.DrawImage(g.disp, &DispOps)
+}
+
+func (g *Game) Layout(outWidth, outHeight int) (int, int) {
+	return g.vm.Layout()
+}
+
+func main() {
+	quirksName := flag.String("quirks", "schip", "quirks preset: vip, schip, or xochip")
+	quirksFile := flag.String("quirks-file", "quirks.json", "path to a JSON file of per-ROM quirks overrides")
+	beepHz := flag.Float64("beep-hz", DefaultBeepHz, "sound timer beep frequency, in Hz")
+	statePath := flag.String("state", "", "path to a save state to boot from, in place of a fresh start")
+	debug := flag.Bool("debug", false, "start halted at an interactive debugger prompt")
+	tracePath := flag.String("trace", "", "path to log one disassembled line per executed instruction")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "usage: chippy [-quirks vip|schip|xochip] [-quirks-file path] [-beep-hz hz] [-state path] [-debug] [-trace path] <program>\n")
+		os.Exit(1)
+	}
+	rom := flag.Arg(0)
+
+	preset, err := chip8.ParseQuirksPreset(*quirksName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	overrides, err := chip8.LoadQuirksOverrides(*quirksFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	quirks := chip8.QuirksFor(preset, rom, overrides)
+
+	game, err := NewGame(quirks, *beepHz, rom)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err.Error())
+		os.Exit(1)
+	}
+
+	if *statePath != "" {
+		f, err := os.Open(*statePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		err = game.LoadState(f)
+		f.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "loading state %s: %v\n", *statePath, err)
+			os.Exit(1)
+		}
+	}
+
+	if *tracePath != "" {
+		f, err := os.Create(*tracePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		game.vm.SetTrace(f)
+	}
+
+	if *debug {
+		go NewDebugger(game).Run()
+	}
+
+	ebiten.SetWindowSize(chip8.DispWidth*DispScale, chip8.DispHeight*DispScale)
+	ebiten.SetWindowTitle("Chippy")
+	ebiten.SetScreenClearedEveryFrame(false)
+
+	if err := ebiten.RunGame(game); err != nil && !errors.Is(err, chip8.ErrExited) {
+		fmt.Fprintf(os.Stderr, "%v\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+func ebitenKeymap() map[ebiten.Key]byte {
+	return map[ebiten.Key]byte{
+		ebiten.Key1: 0x1,
+		ebiten.Key2: 0x2,
+		ebiten.Key3: 0x3,
+		ebiten.Key4: 0xC,
+		ebiten.KeyQ: 0x4,
+		ebiten.KeyW: 0x5,
+		ebiten.KeyE: 0x6,
+		ebiten.KeyR: 0xD,
+		ebiten.KeyA: 0x7,
+		ebiten.KeyS: 0x8,
+		ebiten.KeyD: 0x9,
+		ebiten.KeyF: 0xE,
+		ebiten.KeyZ: 0xA,
+		ebiten.KeyX: 0x0,
+		ebiten.KeyC: 0xB,
+		ebiten.KeyV: 0xF,
+	}
+}